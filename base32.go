@@ -5,6 +5,7 @@
 package base32
 
 import (
+	"encoding/binary"
 	"fmt"
 )
 
@@ -18,14 +19,73 @@ const (
 	OrderInversed
 )
 
+// NoPadding instructs an Alphabet to omit padding entirely, matching the
+// historical Rspamd-compatible behavior of this package.
+const NoPadding rune = -1
+
+// StdPadding is the '=' padding character defined by RFC 4648.
+const StdPadding rune = '='
+
 // Alphabet defines the 32 characters used for Base32 encoding
 type Alphabet struct {
 	encodeSymbols [AlphabetSize]byte
 	decodeBytes   [256]byte
 	encodeOrder   EncodeOrder
+	padChar       rune
+	strict        bool
+
+	// encodeScatter and decodeScatter are precomputed fast-path tables built
+	// by buildTables; see buildEncodeScatter and buildDecodeScatter. They're held by
+	// pointer so that WithPadding and Strict, which copy the Alphabet by
+	// value, stay cheap instead of duplicating ~26 KB of tables per call.
+	encodeScatter *[5][256]uint64
+	decodeScatter *[8][256]uint64
+}
+
+// buildTables populates a's fast-path scatter tables from its encodeSymbols,
+// decodeBytes and encodeOrder. Called once, after those fields are set, by
+// NewAlphabet and by init for the predefined alphabets.
+func (a *Alphabet) buildTables() {
+	encodeScatter := buildEncodeScatter(a.encodeOrder)
+	decodeScatter := buildDecodeScatter(a.encodeSymbols, a.decodeBytes, a.encodeOrder)
+	a.encodeScatter = &encodeScatter
+	a.decodeScatter = &decodeScatter
+}
+
+// WithPadding returns a copy of the alphabet with the given padding
+// character. Pass NoPadding to disable padding entirely. Panics if padding
+// is above 0xff, is '\r' or '\n', or is contained in the alphabet, mirroring
+// encoding/base32's Encoding.WithPadding.
+func (a Alphabet) WithPadding(padding rune) *Alphabet {
+	switch {
+	case padding == NoPadding:
+	case padding < 0 || padding > 255 || padding == '\r' || padding == '\n':
+		panic("base32: invalid padding")
+	}
+
+	for _, b := range a.encodeSymbols {
+		if rune(b) == padding {
+			panic("base32: padding contained in alphabet")
+		}
+	}
+
+	a.padChar = padding
+	return &a
+}
+
+// Strict returns a copy of the alphabet that rejects input where the
+// unused bits of the final character are not zero, mirroring
+// encoding/base32's Encoding.Strict.
+func (a Alphabet) Strict() *Alphabet {
+	a.strict = true
+	return &a
 }
 
-// NewAlphabet creates a new alphabet from a string with specified encode order
+// NewAlphabet creates a new alphabet from a string with specified encode order.
+// The returned alphabet defaults to StdPadding, unless the alphabet's 32
+// symbols already contain '=', in which case it defaults to NoPadding
+// instead of silently colliding with a data symbol; call WithPadding
+// explicitly to pick a different padding character in that case.
 func NewAlphabet(alphabet string, order EncodeOrder) (*Alphabet, error) {
 	if len(alphabet) != AlphabetSize {
 		return nil, fmt.Errorf("invalid length - must be %d bytes", AlphabetSize)
@@ -56,11 +116,19 @@ func NewAlphabet(alphabet string, order EncodeOrder) (*Alphabet, error) {
 		decodeBytes[b] = byte(i)
 	}
 
-	return &Alphabet{
+	padChar := StdPadding
+	if duplicates[byte(StdPadding)] {
+		padChar = NoPadding
+	}
+
+	a := &Alphabet{
 		encodeSymbols: symbols,
 		decodeBytes:   decodeBytes,
 		encodeOrder:   order,
-	}, nil
+		padChar:       padChar,
+	}
+	a.buildTables()
+	return a, nil
 }
 
 // Helper function to initialize decode tables
@@ -85,13 +153,15 @@ var ZBASE32 = &Alphabet{
 	encodeSymbols: [32]byte{'y', 'b', 'n', 'd', 'r', 'f', 'g', '8', 'e', 'j', 'k', 'm', 'c', 'p', 'q', 'x', 'o', 't', '1', 'u', 'w', 'i', 's', 'z', 'a', '3', '4', '5', 'h', '7', '6', '9'},
 	decodeBytes:   initDecodeTable("ybndrfg8ejkmcpqxot1uwisza345h769"),
 	encodeOrder:   OrderInversed,
+	padChar:       NoPadding,
 }
 
-// RFC4648 alphabet with normal order
+// RFC4648 alphabet with normal order, padded with '=' per RFC 4648
 var RFC4648 = &Alphabet{
 	encodeSymbols: [32]byte{'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z', '2', '3', '4', '5', '6', '7'},
 	decodeBytes:   initDecodeTable("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"),
 	encodeOrder:   OrderNormal,
+	padChar:       StdPadding,
 }
 
 // BECH32 alphabet with normal order
@@ -99,6 +169,41 @@ var BECH32 = &Alphabet{
 	encodeSymbols: [32]byte{'q', 'p', 'z', 'r', 'y', '9', 'x', '8', 'g', 'f', '2', 't', 'v', 'd', 'w', '0', 's', '3', 'j', 'n', '5', '4', 'k', 'h', 'c', 'e', '6', 'm', 'u', 'a', '7', 'l'},
 	decodeBytes:   initDecodeTable("qpzry9x8gf2tvdw0s3jn54khce6mua7l"),
 	encodeOrder:   OrderNormal,
+	padChar:       NoPadding,
+}
+
+// HEX32 is the RFC 4648 §7 "Extended Hex Alphabet", used by DNSSEC NSEC3
+// owner names and other formats that need a base32 encoding which preserves
+// byte ordering when sorted lexicographically. Decoding accepts both cases,
+// since DNSSEC wire format is lower-case while zone files are upper-case.
+var HEX32 = newHex32Alphabet()
+
+func newHex32Alphabet() *Alphabet {
+	const hexAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUV"
+
+	var symbols [32]byte
+	copy(symbols[:], hexAlphabet)
+
+	decodeBytes := initDecodeTable(hexAlphabet)
+	for i := 0; i < len(hexAlphabet); i++ {
+		c := hexAlphabet[i]
+		if c >= 'A' && c <= 'Z' {
+			decodeBytes[c-'A'+'a'] = byte(i)
+		}
+	}
+
+	return &Alphabet{
+		encodeSymbols: symbols,
+		decodeBytes:   decodeBytes,
+		encodeOrder:   OrderNormal,
+		padChar:       StdPadding,
+	}
+}
+
+func init() {
+	for _, a := range []*Alphabet{ZBASE32, RFC4648, BECH32, HEX32} {
+		a.buildTables()
+	}
 }
 
 // DecodeError represents an error during decoding
@@ -115,16 +220,139 @@ func (e DecodeError) Error() string {
 	return e.Msg
 }
 
-// EncodedLen returns the length of encoding for the given input length
-func EncodedLen(bytesLen int) int {
-	minBytes := bytesLen / 5
-	rem := bytesLen % 5
-	return minBytes*8 + rem*2 + 1
+// EncodedLen returns the length of encoding for the given input length using
+// alphabet. With NoPadding this is an upper bound (see EncodeToSlice); with
+// padding enabled it is exact, rounding up to a multiple of 8.
+func EncodedLen(bytesLen int, alphabet *Alphabet) int {
+	if alphabet.padChar == NoPadding {
+		minBytes := bytesLen / 5
+		rem := bytesLen % 5
+		return minBytes*8 + rem*2 + 1
+	}
+
+	groups := (bytesLen + 4) / 5
+	return groups * 8
+}
+
+// decodeSentinel marks a decodeScatter entry for an input byte that is not a
+// valid character of the alphabet; a block containing one fails fast.
+const decodeSentinel = ^uint64(0)
+
+// buildEncodeScatter computes, for each of the 5 byte positions of an
+// encoding group and each possible input byte value, the raw (pre-alphabet)
+// 5-bit code contributed to each of the 8 output symbols by that byte alone,
+// packed one code per byte lane of a uint64 (symbol i in bits [8*i, 8*i+5)).
+// Because the bit-packing performed by encodeScalar only ORs together
+// disjoint bit ranges, the contributions of all 5 positions can later be
+// OR-combined to reproduce the exact codes encodeScalar would have produced
+// for the real 5-byte group. Using an identity alphabet here means the codes
+// come back unsubstituted, ready for encodeScatter to store directly.
+func buildEncodeScatter(order EncodeOrder) [5][256]uint64 {
+	var identitySymbols [AlphabetSize]byte
+	for i := range identitySymbols {
+		identitySymbols[i] = byte(i)
+	}
+	idAlphabet := &Alphabet{encodeSymbols: identitySymbols, encodeOrder: order, padChar: NoPadding}
+
+	var table [5][256]uint64
+	var buf [5]byte
+	var out [8]byte
+	for pos := 0; pos < 5; pos++ {
+		for b := 0; b < 256; b++ {
+			for i := range buf {
+				buf[i] = 0
+			}
+			buf[pos] = byte(b)
+			encodeScalar(buf[:], out[:], idAlphabet)
+
+			var packed uint64
+			for slot, code := range out {
+				packed |= uint64(code) << uint(8*slot)
+			}
+			table[pos][b] = packed
+		}
+	}
+	return table
+}
+
+// buildDecodeScatter computes, for each of the 8 character positions of a
+// decoding group and each possible input byte, the raw 40-bit contribution
+// (5 output bytes, one per byte lane of a uint64) that character makes when
+// decoded alone at that position, with the other 7 positions held at the
+// character that decodes to code 0. Invalid characters get decodeSentinel.
+// OR-combining the 8 positions' contributions for a real group reproduces
+// exactly what decodeScalar would have emitted for it.
+func buildDecodeScatter(symbols [AlphabetSize]byte, decodeBytes [256]byte, order EncodeOrder) [8][256]uint64 {
+	zeroChar := symbols[0]
+	tmpAlphabet := &Alphabet{encodeSymbols: symbols, decodeBytes: decodeBytes, encodeOrder: order, padChar: NoPadding}
+
+	var table [8][256]uint64
+	var buf [8]byte
+	var out [5]byte
+	for pos := 0; pos < 8; pos++ {
+		for c := 0; c < 256; c++ {
+			if decodeBytes[c] == 0xff {
+				table[pos][c] = decodeSentinel
+				continue
+			}
+
+			for i := range buf {
+				buf[i] = zeroChar
+			}
+			buf[pos] = byte(c)
+			decodeScalar(buf[:], out[:], tmpAlphabet)
+
+			var packed uint64
+			for slot, b := range out {
+				packed |= uint64(b) << uint(8*slot)
+			}
+			table[pos][c] = packed
+		}
+	}
+	return table
 }
 
 // EncodeToSlice encodes input using the specified alphabet into the output buffer
-// Returns the number of bytes written to the output buffer
+// Returns the number of bytes written to the output buffer. Full 5-byte
+// groups are processed through alphabet's scatter table; a short final group
+// falls back to the scalar algorithm.
 func EncodeToSlice(input []byte, output []byte, alphabet *Alphabet) int {
+	full := len(input) - len(input)%5
+	o := 0
+
+	for i := 0; i < full; i += 5 {
+		var packed uint64
+		for pos := 0; pos < 5; pos++ {
+			packed |= alphabet.encodeScatter[pos][input[i+pos]]
+		}
+
+		var raw [8]byte
+		binary.LittleEndian.PutUint64(raw[:], packed)
+		for _, code := range raw {
+			output[o] = alphabet.encodeSymbols[code&0x1F]
+			o++
+		}
+	}
+
+	o += encodeScalar(input[full:], output[o:], alphabet)
+
+	if alphabet.padChar != NoPadding {
+		padded := (o + 7) / 8 * 8
+		pad := byte(alphabet.padChar)
+		for o < padded {
+			output[o] = pad
+			o++
+		}
+	}
+
+	return o
+}
+
+// encodeScalar is the branch-based reference implementation EncodeToSlice
+// falls back to for a less-than-one-group tail, and that buildEncodeScatter
+// uses (with an identity alphabet) to derive the fast-path tables. It never
+// emits padding; callers that need it add it after calling this function.
+func encodeScalar(input []byte, output []byte, alphabet *Alphabet) int {
 	encodeTable := alphabet.encodeSymbols
 	remain := int32(-1)
 	o := 0
@@ -234,7 +462,7 @@ func EncodeToSlice(input []byte, output []byte, alphabet *Alphabet) int {
 
 // EncodeAlphabet encodes input using the specified alphabet
 func EncodeAlphabet(input []byte, alphabet *Alphabet) string {
-	encodedSize := EncodedLen(len(input))
+	encodedSize := EncodedLen(len(input), alphabet)
 	buf := make([]byte, encodedSize)
 	encLen := EncodeToSlice(input, buf, alphabet)
 	return string(buf[:encLen])
@@ -246,15 +474,20 @@ func Encode(input []byte) string {
 }
 
 // DecodedLen returns the maximum decoded length for the given encoded length
-func DecodedLen(bytesLen int) int {
-	fullChunks := bytesLen / 8
-	remainder := bytesLen % 8
-	return fullChunks*5 + remainder
+// using alphabet.
+func DecodedLen(bytesLen int, alphabet *Alphabet) int {
+	if alphabet.padChar == NoPadding {
+		fullChunks := bytesLen / 8
+		remainder := bytesLen % 8
+		return fullChunks*5 + remainder
+	}
+
+	return bytesLen / 8 * 5
 }
 
 // DecodeAlphabet decodes input using the specified alphabet
 func DecodeAlphabet(input []byte, alphabet *Alphabet) ([]byte, error) {
-	buffer := make([]byte, DecodedLen(len(input)))
+	buffer := make([]byte, DecodedLen(len(input), alphabet))
 	actualLen, err := DecodeAlphabetToSlice(input, buffer, alphabet)
 	if err != nil {
 		return nil, err
@@ -262,16 +495,79 @@ func DecodeAlphabet(input []byte, alphabet *Alphabet) ([]byte, error) {
 	return buffer[:actualLen], nil
 }
 
-// DecodeAlphabetToSlice decodes input using the specified alphabet into the provided buffer
-// Returns the number of bytes written and any error
+// DecodeAlphabetToSlice decodes input using the specified alphabet into the provided buffer.
+// Returns the number of bytes written and any error. When alphabet has padding
+// enabled, trailing pad characters are validated and stripped first; a wrong
+// number of them (anything other than 0, 1, 3, 4 or 6 per RFC 4648 §6) is an
+// error. With Strict, non-zero unused bits in the final character are also
+// rejected, for both OrderNormal and OrderInversed. Full 8-character groups are processed through
+// alphabet's scatter table; a short final group falls back to the scalar
+// algorithm.
 func DecodeAlphabetToSlice(input []byte, buffer []byte, alphabet *Alphabet) (int, error) {
+	encoded := input
+
+	if alphabet.padChar != NoPadding {
+		if len(encoded)%8 != 0 {
+			return 0, DecodeError{Msg: "invalid padding length", Offset: len(encoded), Byte: byte(alphabet.padChar)}
+		}
+
+		padByte := byte(alphabet.padChar)
+		padLen := 0
+		for padLen < len(encoded) && encoded[len(encoded)-1-padLen] == padByte {
+			padLen++
+		}
+
+		switch padLen {
+		case 0, 1, 3, 4, 6:
+		default:
+			return 0, DecodeError{Msg: "invalid number of padding characters", Offset: len(encoded) - padLen, Byte: padByte}
+		}
+
+		encoded = encoded[:len(encoded)-padLen]
+	}
+
+	full := len(encoded) - len(encoded)%8
+	o := 0
+
+	for i := 0; i < full; i += 8 {
+		var packed uint64
+		for pos := 0; pos < 8; pos++ {
+			c := encoded[i+pos]
+			v := alphabet.decodeScatter[pos][c]
+			if v == decodeSentinel {
+				return 0, DecodeError{Msg: "invalid byte", Offset: i + pos, Byte: c}
+			}
+			packed |= v
+		}
+
+		var raw [8]byte
+		binary.LittleEndian.PutUint64(raw[:], packed)
+		o += copy(buffer[o:], raw[:5])
+	}
+
+	n, err := decodeScalar(encoded[full:], buffer[o:], alphabet)
+	if err != nil {
+		de := err.(DecodeError)
+		de.Offset += full
+		return 0, de
+	}
+
+	return o + n, nil
+}
+
+// decodeScalar is the branch-based reference implementation
+// DecodeAlphabetToSlice falls back to for a less-than-one-group tail, and
+// that buildDecodeScatter uses to derive the fast-path tables. encoded must
+// already have any padding stripped; offsets in returned errors are relative
+// to encoded.
+func decodeScalar(encoded []byte, buffer []byte, alphabet *Alphabet) (int, error) {
 	processedBits := 0
 	acc := uint32(0)
 	o := 0
 
 	if alphabet.encodeOrder == OrderInversed {
 		// Rspamd compatible decoding with reversed bit order
-		for i, c := range input {
+		for i, c := range encoded {
 			if processedBits >= 8 {
 				// Emit from left to right
 				processedBits -= 8
@@ -294,12 +590,23 @@ func DecodeAlphabetToSlice(input []byte, buffer []byte, alphabet *Alphabet) (int
 		}
 
 		if processedBits > 0 {
+			// acc>>8 holds the unused bits of the final character(s), the
+			// same quantity the OrderNormal branch below checks via acc
+			// after its own trailing emit; Strict requires it to be zero.
+			if alphabet.strict && acc>>8 != 0 {
+				return 0, DecodeError{
+					Msg:    "illegal unused bits in final character",
+					Offset: len(encoded) - 1,
+					Byte:   encoded[len(encoded)-1],
+				}
+			}
+
 			buffer[o] = byte(acc & 0xFF)
 			o++
 		}
 	} else {
 		// Standard decoding
-		for i, c := range input {
+		for i, c := range encoded {
 			decoded := alphabet.decodeBytes[c]
 			if decoded == 0xff {
 				return 0, DecodeError{
@@ -320,6 +627,14 @@ func DecodeAlphabetToSlice(input []byte, buffer []byte, alphabet *Alphabet) (int
 				acc = acc & ((1 << processedBits) - 1)
 			}
 		}
+
+		if alphabet.strict && processedBits > 0 && acc != 0 {
+			return 0, DecodeError{
+				Msg:    "illegal unused bits in final character",
+				Offset: len(encoded) - 1,
+				Byte:   encoded[len(encoded)-1],
+			}
+		}
 	}
 
 	return o, nil
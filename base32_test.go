@@ -51,16 +51,16 @@ func TestRFC4648Compatibility(t *testing.T) {
 		expected string
 	}{
 		{"hello", "NBSWY3DP"},
-		{"test123", "ORSXG5BRGIZQ"},
+		{"test123", "ORSXG5BRGIZQ===="},
 		{"", ""},
-		{"a", "ME"},
-		{"aa", "MFQQ"},
-		{"aaa", "MFQWC"},
-		{"aaaa", "MFQWCYI"},
+		{"a", "ME======"},
+		{"aa", "MFQQ===="},
+		{"aaa", "MFQWC==="},
+		{"aaaa", "MFQWCYI="},
 		{"aaaaa", "MFQWCYLB"},
-		{"aaaaaa", "MFQWCYLBME"},
-		{"aaaaaaa", "MFQWCYLBMFQQ"},
-		{"aaaaaaaa", "MFQWCYLBMFQWC"},
+		{"aaaaaa", "MFQWCYLBME======"},
+		{"aaaaaaa", "MFQWCYLBMFQQ===="},
+		{"aaaaaaaa", "MFQWCYLBMFQWC==="},
 	}
 
 	for _, test := range tests {
@@ -140,7 +140,7 @@ func TestInvalidDecoding(t *testing.T) {
 
 func TestEncodeToSlice(t *testing.T) {
 	input := []byte("hello")
-	output := make([]byte, EncodedLen(len(input)))
+	output := make([]byte, EncodedLen(len(input), ZBASE32))
 	
 	n := EncodeToSlice(input, output, ZBASE32)
 	result := string(output[:n])
@@ -153,7 +153,7 @@ func TestEncodeToSlice(t *testing.T) {
 
 func TestDecodeToSlice(t *testing.T) {
 	input := []byte("em3ags7p")
-	output := make([]byte, DecodedLen(len(input)))
+	output := make([]byte, DecodedLen(len(input), ZBASE32))
 	
 	n, err := DecodeAlphabetToSlice(input, output, ZBASE32)
 	if err != nil {
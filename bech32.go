@@ -0,0 +1,223 @@
+package base32
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bech32Variant distinguishes the original Bech32 checksum (BIP-173) from
+// the Bech32m checksum (BIP-350) used by newer segwit address versions.
+type Bech32Variant int
+
+const (
+	Bech32 Bech32Variant = iota
+	Bech32m
+)
+
+// bech32Const and bech32mConst are the values the polymod of a valid Bech32
+// or Bech32m string, respectively, must equal; createChecksum XORs the
+// matching one into the polymod when generating a checksum. Per BIP-173 and
+// BIP-350.
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// maxBech32Length is the combined hrp + '1' + data + checksum length limit
+// from BIP-173.
+const maxBech32Length = 90
+
+// bech32Generator holds the five BCH generator polynomials used by polymod.
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// polymod computes the Bech32 checksum polynomial over a sequence of 5-bit
+// values (hrp-expanded characters followed by data and, when verifying, the
+// checksum itself).
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, gen := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen
+			}
+		}
+	}
+	return chk
+}
+
+// hrpExpand spreads the high and low bits of each hrp byte into separate
+// 5-bit values, as required before computing or verifying a checksum.
+func hrpExpand(hrp string) []byte {
+	ret := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]>>5)
+	}
+	ret = append(ret, 0)
+	for i := 0; i < len(hrp); i++ {
+		ret = append(ret, hrp[i]&31)
+	}
+	return ret
+}
+
+// createChecksum computes the six 5-bit checksum values for hrp and data
+// under the given variant.
+func createChecksum(hrp string, data []byte, variant Bech32Variant) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+
+	target := uint32(bech32Const)
+	if variant == Bech32m {
+		target = bech32mConst
+	}
+
+	mod := polymod(values) ^ target
+
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+// verifyChecksum checks data (which includes its trailing 6-value checksum)
+// against hrp and reports which variant, if any, it is valid under.
+func verifyChecksum(hrp string, data []byte) (Bech32Variant, bool) {
+	switch polymod(append(hrpExpand(hrp), data...)) {
+	case bech32Const:
+		return Bech32, true
+	case bech32mConst:
+		return Bech32m, true
+	default:
+		return 0, false
+	}
+}
+
+func isValidHRPByte(c byte) bool {
+	return c >= 33 && c <= 126
+}
+
+// EncodeBech32 encodes data (a sequence of 5-bit values, such as produced by
+// ConvertBits) together with the human-readable part hrp into a Bech32 or
+// Bech32m string per BIP-173/BIP-350, using the BECH32 alphabet.
+func EncodeBech32(hrp string, data []byte, variant Bech32Variant) (string, error) {
+	if hrp == "" {
+		return "", fmt.Errorf("bech32: human-readable part must not be empty")
+	}
+
+	for i := 0; i < len(hrp); i++ {
+		c := hrp[i]
+		if !isValidHRPByte(c) {
+			return "", fmt.Errorf("bech32: invalid hrp byte: %#02x", c)
+		}
+		if c >= 'A' && c <= 'Z' {
+			return "", fmt.Errorf("bech32: hrp must not contain upper-case letters")
+		}
+	}
+
+	for _, v := range data {
+		if v > 31 {
+			return "", fmt.Errorf("bech32: data value out of range: %d", v)
+		}
+	}
+
+	if len(hrp)+1+len(data)+6 > maxBech32Length {
+		return "", fmt.Errorf("bech32: combined length exceeds %d characters", maxBech32Length)
+	}
+
+	checksum := createChecksum(hrp, data, variant)
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range data {
+		sb.WriteByte(BECH32.encodeSymbols[v])
+	}
+	for _, v := range checksum {
+		sb.WriteByte(BECH32.encodeSymbols[v])
+	}
+
+	return sb.String(), nil
+}
+
+// DecodeBech32 splits s into its human-readable part and 5-bit data values
+// (with the trailing checksum removed) and reports which variant, Bech32 or
+// Bech32m, its checksum matches.
+func DecodeBech32(s string) (hrp string, data []byte, variant Bech32Variant, err error) {
+	if len(s) < 8 || len(s) > maxBech32Length {
+		return "", nil, 0, fmt.Errorf("bech32: invalid length: %d", len(s))
+	}
+
+	if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+		return "", nil, 0, fmt.Errorf("bech32: mixed-case string")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, fmt.Errorf("bech32: missing or misplaced separator")
+	}
+
+	hrp = s[:sep]
+	for i := 0; i < len(hrp); i++ {
+		if !isValidHRPByte(hrp[i]) {
+			return "", nil, 0, fmt.Errorf("bech32: invalid hrp byte: %#02x", hrp[i])
+		}
+	}
+
+	encoded := s[sep+1:]
+	values := make([]byte, len(encoded))
+	for i := 0; i < len(encoded); i++ {
+		d := BECH32.decodeBytes[encoded[i]]
+		if d == 0xff {
+			return "", nil, 0, DecodeError{Msg: "invalid bech32 data byte", Offset: sep + 1 + i, Byte: encoded[i]}
+		}
+		values[i] = d
+	}
+
+	v, ok := verifyChecksum(hrp, values)
+	if !ok {
+		return "", nil, 0, fmt.Errorf("bech32: invalid checksum")
+	}
+
+	return hrp, values[:len(values)-6], v, nil
+}
+
+// ConvertBits regroups data, a sequence of fromBits-wide values, into a
+// sequence of toBits-wide values (e.g. 8-to-5 before Bech32 encoding, or
+// 5-to-8 after decoding). When pad is true, a short final group is padded
+// with zero bits; when false, a non-empty short final group with non-zero
+// padding bits is an error.
+func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint8
+	maxv := uint32(1)<<toBits - 1
+	maxAcc := uint32(1)<<(fromBits+toBits-1) - 1
+
+	ret := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, value := range data {
+		if value>>fromBits != 0 {
+			return nil, fmt.Errorf("bech32: value out of range for %d bits: %d", fromBits, value)
+		}
+
+		acc = ((acc << fromBits) | uint32(value)) & maxAcc
+		bits += fromBits
+
+		for bits >= toBits {
+			bits -= toBits
+			ret = append(ret, byte((acc>>bits)&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			ret = append(ret, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, fmt.Errorf("bech32: illegal zero padding")
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,150 @@
+package base32
+
+import (
+	"strings"
+	"testing"
+)
+
+// Generic Bech32 checksum test vectors from BIP-173.
+func TestBech32ValidChecksums(t *testing.T) {
+	vectors := []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	}
+
+	for _, v := range vectors {
+		t.Run(v, func(t *testing.T) {
+			hrp, data, variant, err := DecodeBech32(v)
+			if err != nil {
+				t.Fatalf("DecodeBech32(%q) failed: %v", v, err)
+			}
+			if variant != Bech32 {
+				t.Errorf("DecodeBech32(%q) variant = %v, want Bech32", v, variant)
+			}
+
+			reencoded, err := EncodeBech32(hrp, data, variant)
+			if err != nil {
+				t.Fatalf("EncodeBech32 failed: %v", err)
+			}
+			if reencoded != strings.ToLower(v) {
+				t.Errorf("re-encoded = %q, want %q", reencoded, strings.ToLower(v))
+			}
+		})
+	}
+}
+
+// Generic Bech32m checksum test vectors from BIP-350.
+func TestBech32mValidChecksums(t *testing.T) {
+	vectors := []string{
+		"A1LQFN3A",
+		"a1lqfn3a",
+		"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+		"?1v759aa",
+	}
+
+	for _, v := range vectors {
+		t.Run(v, func(t *testing.T) {
+			hrp, data, variant, err := DecodeBech32(v)
+			if err != nil {
+				t.Fatalf("DecodeBech32(%q) failed: %v", v, err)
+			}
+			if variant != Bech32m {
+				t.Errorf("DecodeBech32(%q) variant = %v, want Bech32m", v, variant)
+			}
+
+			reencoded, err := EncodeBech32(hrp, data, variant)
+			if err != nil {
+				t.Fatalf("EncodeBech32 failed: %v", err)
+			}
+			if reencoded != strings.ToLower(v) {
+				t.Errorf("re-encoded = %q, want %q", reencoded, strings.ToLower(v))
+			}
+		})
+	}
+}
+
+// TestBech32SegwitAddressRoundtrip decodes a well-known BIP-173 P2WPKH
+// address, recovers the witness version and program via ConvertBits, and
+// checks that re-encoding reproduces the original address.
+func TestBech32SegwitAddressRoundtrip(t *testing.T) {
+	const addr = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+
+	hrp, data, variant, err := DecodeBech32(addr)
+	if err != nil {
+		t.Fatalf("DecodeBech32 failed: %v", err)
+	}
+	if hrp != "bc" {
+		t.Errorf("hrp = %q, want %q", hrp, "bc")
+	}
+	if variant != Bech32 {
+		t.Errorf("variant = %v, want Bech32", variant)
+	}
+
+	version := data[0]
+	if version != 0 {
+		t.Errorf("witness version = %d, want 0", version)
+	}
+
+	program, err := ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		t.Fatalf("ConvertBits 5->8 failed: %v", err)
+	}
+	if len(program) != 20 {
+		t.Errorf("witness program length = %d, want 20 (P2WPKH)", len(program))
+	}
+
+	regrouped, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		t.Fatalf("ConvertBits 8->5 failed: %v", err)
+	}
+
+	reencoded, err := EncodeBech32(hrp, append([]byte{version}, regrouped...), variant)
+	if err != nil {
+		t.Fatalf("EncodeBech32 failed: %v", err)
+	}
+	if reencoded != addr {
+		t.Errorf("re-encoded address = %q, want %q", reencoded, addr)
+	}
+}
+
+func TestConvertBitsRoundtrip(t *testing.T) {
+	original := []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99}
+
+	fiveBit, err := ConvertBits(original, 8, 5, true)
+	if err != nil {
+		t.Fatalf("ConvertBits 8->5 failed: %v", err)
+	}
+
+	back, err := ConvertBits(fiveBit, 5, 8, false)
+	if err != nil {
+		t.Fatalf("ConvertBits 5->8 failed: %v", err)
+	}
+
+	if string(back) != string(original) {
+		t.Errorf("roundtrip = %v, want %v", back, original)
+	}
+}
+
+func TestEncodeBech32RejectsMixedCaseHRP(t *testing.T) {
+	if _, err := EncodeBech32("Bc", []byte{0, 1, 2}, Bech32); err == nil {
+		t.Error("expected error for upper-case hrp")
+	}
+}
+
+func TestDecodeBech32RejectsMixedCase(t *testing.T) {
+	if _, _, _, err := DecodeBech32("A12uel5L"); err == nil {
+		t.Error("expected error for mixed-case input")
+	}
+}
+
+func TestDecodeBech32RejectsBadChecksum(t *testing.T) {
+	if _, _, _, err := DecodeBech32("a12uel5x"); err == nil {
+		t.Error("expected error for invalid checksum")
+	}
+}
@@ -0,0 +1,148 @@
+package base32
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// TestFastPathMatchesScalar checks that EncodeToSlice/DecodeAlphabetToSlice,
+// which dispatch full groups through the alphabet's scatter tables, produce
+// byte-identical output to the scalar reference implementation across a
+// range of lengths that straddle the 5-byte/8-character group boundary.
+func TestFastPathMatchesScalar(t *testing.T) {
+	alphabets := []*Alphabet{ZBASE32, RFC4648, BECH32, HEX32}
+	names := []string{"ZBASE32", "RFC4648", "BECH32", "HEX32"}
+
+	rng := rand.New(rand.NewSource(1))
+
+	for i, alphabet := range alphabets {
+		t.Run(names[i], func(t *testing.T) {
+			for _, n := range []int{0, 1, 4, 5, 6, 9, 10, 11, 37, 64, 1000} {
+				data := make([]byte, n)
+				rng.Read(data)
+
+				fastOut := make([]byte, EncodedLen(n, alphabet))
+				fastLen := EncodeToSlice(data, fastOut, alphabet)
+
+				scalarOut := make([]byte, EncodedLen(n, alphabet))
+				scalarLen := encodeScalar(data, scalarOut, alphabet)
+				if alphabet.padChar != NoPadding {
+					padded := (scalarLen + 7) / 8 * 8
+					for scalarLen < padded {
+						scalarOut[scalarLen] = byte(alphabet.padChar)
+						scalarLen++
+					}
+				}
+
+				if fastLen != scalarLen || !bytes.Equal(fastOut[:fastLen], scalarOut[:scalarLen]) {
+					t.Fatalf("len %d: fast encode %q != scalar encode %q", n, fastOut[:fastLen], scalarOut[:scalarLen])
+				}
+
+				decFast := make([]byte, DecodedLen(fastLen, alphabet))
+				nFast, err := DecodeAlphabetToSlice(fastOut[:fastLen], decFast, alphabet)
+				if err != nil {
+					t.Fatalf("len %d: fast decode failed: %v", n, err)
+				}
+
+				stripped := fastOut[:fastLen]
+				if alphabet.padChar != NoPadding {
+					end := len(stripped)
+					for end > 0 && stripped[end-1] == byte(alphabet.padChar) {
+						end--
+					}
+					stripped = stripped[:end]
+				}
+
+				decScalar := make([]byte, DecodedLen(fastLen, alphabet))
+				nScalar, err := decodeScalar(stripped, decScalar, alphabet)
+				if err != nil {
+					t.Fatalf("len %d: scalar decode failed: %v", n, err)
+				}
+
+				if nFast != nScalar || !bytes.Equal(decFast[:nFast], decScalar[:nScalar]) {
+					t.Fatalf("len %d: fast decode %v != scalar decode %v", n, decFast[:nFast], decScalar[:nScalar])
+				}
+				if !bytes.Equal(decFast[:nFast], data) {
+					t.Fatalf("len %d: decode %v != original %v", n, decFast[:nFast], data)
+				}
+			}
+		})
+	}
+}
+
+// TestFastPathDecodeInvalidByte checks that an invalid character inside a
+// full 8-character group is still reported with the same offset the scalar
+// decoder would use.
+func TestFastPathDecodeInvalidByte(t *testing.T) {
+	encoded := []byte("NBSWY3DPNBSWY3DP")
+	encoded[9] = '@'
+
+	buf := make([]byte, DecodedLen(len(encoded), RFC4648))
+	_, err := DecodeAlphabetToSlice(encoded, buf, RFC4648)
+	if err == nil {
+		t.Fatal("expected error for invalid byte in full group")
+	}
+
+	de, ok := err.(DecodeError)
+	if !ok {
+		t.Fatalf("expected DecodeError, got %T", err)
+	}
+	if de.Offset != 9 || de.Byte != '@' {
+		t.Errorf("DecodeError = %+v, want Offset=9 Byte='@'", de)
+	}
+}
+
+func benchmarkBuf(size int) []byte {
+	data := make([]byte, size)
+	rng := rand.New(rand.NewSource(42))
+	rng.Read(data)
+	return data
+}
+
+// Benchmarks below exercise the scatter-table fast path (64 KiB buffers are
+// many full 5-byte/8-character groups, so the scalar tail is negligible).
+
+func BenchmarkEncodeZBase32_64KiB(b *testing.B) {
+	data := benchmarkBuf(64 * 1024)
+	out := make([]byte, EncodedLen(len(data), ZBASE32))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncodeToSlice(data, out, ZBASE32)
+	}
+}
+
+func BenchmarkDecodeZBase32_64KiB(b *testing.B) {
+	data := benchmarkBuf(64 * 1024)
+	encoded := make([]byte, EncodedLen(len(data), ZBASE32))
+	encLen := EncodeToSlice(data, encoded, ZBASE32)
+	out := make([]byte, DecodedLen(encLen, ZBASE32))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DecodeAlphabetToSlice(encoded[:encLen], out, ZBASE32)
+	}
+}
+
+func BenchmarkEncodeRFC4648_64KiB(b *testing.B) {
+	data := benchmarkBuf(64 * 1024)
+	out := make([]byte, EncodedLen(len(data), RFC4648))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncodeToSlice(data, out, RFC4648)
+	}
+}
+
+func BenchmarkDecodeRFC4648_64KiB(b *testing.B) {
+	data := benchmarkBuf(64 * 1024)
+	encoded := make([]byte, EncodedLen(len(data), RFC4648))
+	encLen := EncodeToSlice(data, encoded, RFC4648)
+	out := make([]byte, DecodedLen(encLen, RFC4648))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DecodeAlphabetToSlice(encoded[:encLen], out, RFC4648)
+	}
+}
@@ -0,0 +1,77 @@
+package base32
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Test vectors from RFC 4648 §10.
+func TestHex32RFC4648Vectors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"f", "CO======"},
+		{"fo", "CPNG===="},
+		{"foo", "CPNMU==="},
+		{"foob", "CPNMUOG="},
+		{"fooba", "CPNMUOJ1"},
+		{"foobar", "CPNMUOJ1E8======"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			encoded := EncodeAlphabetString(test.input, HEX32)
+			if encoded != test.expected {
+				t.Errorf("EncodeAlphabetString(%q, HEX32) = %q, want %q", test.input, encoded, test.expected)
+			}
+
+			decoded, err := DecodeAlphabetString(test.expected, HEX32)
+			if err != nil {
+				t.Fatalf("DecodeAlphabetString(%q, HEX32) failed: %v", test.expected, err)
+			}
+			if string(decoded) != test.input {
+				t.Errorf("DecodeAlphabetString(%q, HEX32) = %q, want %q", test.expected, decoded, test.input)
+			}
+		})
+	}
+}
+
+func TestHex32CaseInsensitiveDecode(t *testing.T) {
+	const encoded = "CPNMUOJ1E8======"
+
+	upper, err := DecodeAlphabetString(encoded, HEX32)
+	if err != nil {
+		t.Fatalf("decode upper-case failed: %v", err)
+	}
+
+	lower, err := DecodeAlphabetString(strings.ToLower(encoded), HEX32)
+	if err != nil {
+		t.Fatalf("decode lower-case failed: %v", err)
+	}
+
+	if string(upper) != string(lower) {
+		t.Errorf("upper-case decode %q != lower-case decode %q", upper, lower)
+	}
+	if string(upper) != "foobar" {
+		t.Errorf("decode = %q, want %q", upper, "foobar")
+	}
+}
+
+// ExampleHEX32 demonstrates encoding an NSEC3 owner-name hash (normally the
+// output of iterated salted SHA-1, RFC 5155 §5) as the lower-case base32hex
+// string used in DNSSEC wire format.
+func ExampleHEX32() {
+	// Stand-in for an NSEC3 hash digest (normally a 20-byte SHA-1 output).
+	hash := []byte{
+		0x0d, 0xa1, 0x1b, 0xca, 0x27, 0x74, 0x0c, 0xa0,
+		0x78, 0x9d, 0x6b, 0x6c, 0xd6, 0x2e, 0x8e, 0xa9,
+		0x3e, 0x3c, 0x0c, 0x9f,
+	}
+
+	ownerName := strings.ToLower(EncodeAlphabet(hash, HEX32))
+	fmt.Println(ownerName)
+	// Output: 1mghnih7eg6a0u4tddmdcbkel4v3o34v
+}
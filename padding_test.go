@@ -0,0 +1,116 @@
+package base32
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWithPaddingRoundtrip(t *testing.T) {
+	for n := 0; n <= 12; n++ {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i + 1)
+		}
+
+		encoded := EncodeAlphabet(data, RFC4648)
+		if len(encoded)%8 != 0 {
+			t.Fatalf("len %d: padded output length %d is not a multiple of 8", n, len(encoded))
+		}
+
+		decoded, err := DecodeAlphabet([]byte(encoded), RFC4648)
+		if err != nil {
+			t.Fatalf("len %d: decode failed: %v", n, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("len %d: roundtrip = %v, want %v", n, decoded, data)
+		}
+	}
+}
+
+func TestNoPadding(t *testing.T) {
+	unpadded := RFC4648.WithPadding(NoPadding)
+
+	encoded := EncodeAlphabet([]byte("test123"), unpadded)
+	if encoded != "ORSXG5BRGIZQ" {
+		t.Errorf("NoPadding encode = %q, want %q", encoded, "ORSXG5BRGIZQ")
+	}
+
+	decoded, err := DecodeAlphabet([]byte(encoded), unpadded)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if string(decoded) != "test123" {
+		t.Errorf("NoPadding decode = %q, want %q", decoded, "test123")
+	}
+}
+
+func TestInvalidPaddingCount(t *testing.T) {
+	tests := []string{
+		"MFQWCY==", // 6 data chars + 2 pad: 2 is not a valid RFC 4648 pad count
+		"MFQ=====", // 3 data chars + 5 pad: 5 is not a valid RFC 4648 pad count
+		"M=======", // 1 data char + 7 pad: 7 is not a valid RFC 4648 pad count
+		"=A======", // stray '=' before the contiguous pad block: the trailing
+		// run of 6 '=' is itself a valid count, but the leftover "=A" prefix
+		// contains an embedded '=', which is not a valid alphabet byte
+	}
+
+	for _, in := range tests {
+		if _, err := DecodeAlphabet([]byte(in), RFC4648); err == nil {
+			t.Errorf("DecodeAlphabet(%q) expected error, got none", in)
+		}
+	}
+}
+
+func TestStrictRejectsNonZeroTrailingBits(t *testing.T) {
+	strict := RFC4648.WithPadding(NoPadding).Strict()
+
+	// "ME" decodes cleanly to 'a' (0x61) under the lenient alphabet; flip an
+	// unused low bit of the final symbol to make it non-zero under Strict.
+	if _, err := DecodeAlphabet([]byte("ME"), strict); err != nil {
+		t.Fatalf("expected clean input to decode under Strict, got %v", err)
+	}
+
+	if _, err := DecodeAlphabet([]byte("MF"), strict); err == nil {
+		t.Error("expected Strict to reject non-zero unused bits in final character")
+	}
+}
+
+func TestNewAlphabetDefaultPaddingConflict(t *testing.T) {
+	alphabet, err := NewAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZ234=67", OrderNormal)
+	if err != nil {
+		t.Fatalf("NewAlphabet failed: %v", err)
+	}
+
+	data := []byte{1, 2, 3, 0, 29}
+	encoded := EncodeAlphabet(data, alphabet)
+	decoded, err := DecodeAlphabet([]byte(encoded), alphabet)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Errorf("roundtrip with '=' in the alphabet = %v, want %v", decoded, data)
+	}
+}
+
+func TestStrictRejectsNonZeroTrailingBitsInversed(t *testing.T) {
+	strict := ZBASE32.Strict()
+
+	// "yy" decodes cleanly to a zero byte under the lenient alphabet; "ye"
+	// sets an unused high bit of the second symbol, which Strict rejects.
+	if _, err := DecodeAlphabet([]byte("yy"), strict); err != nil {
+		t.Fatalf("expected clean input to decode under Strict, got %v", err)
+	}
+
+	if _, err := DecodeAlphabet([]byte("ye"), strict); err == nil {
+		t.Error("expected Strict to reject non-zero unused bits in final character")
+	}
+}
+
+func TestAlphabetWithPaddingPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for padding character contained in alphabet")
+		}
+	}()
+	RFC4648.WithPadding('A')
+}
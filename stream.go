@@ -0,0 +1,177 @@
+package base32
+
+import "io"
+
+// encodeBufferSize is a safe upper bound on the number of output characters
+// produced by encoding a single input group of up to 5 bytes (see EncodedLen).
+const encodeBufferSize = 9
+
+// encoder implements streaming encoding on top of EncodeToSlice, buffering
+// input bytes until a full 5-byte group is available.
+type encoder struct {
+	w        io.Writer
+	alphabet *Alphabet
+	err      error
+	buf      [5]byte
+	nbuf     int
+	out      [encodeBufferSize]byte
+}
+
+// NewEncoder returns a new streaming encoder that writes base32-encoded
+// output using alphabet to w. Callers must call Close to flush any partial
+// input group remaining once all data has been written.
+func NewEncoder(w io.Writer, alphabet *Alphabet) io.WriteCloser {
+	return &encoder{w: w, alphabet: alphabet}
+}
+
+// Write implements io.Writer. Complete 5-byte input groups are encoded and
+// flushed immediately; a trailing partial group is buffered until more data
+// arrives or Close is called.
+func (e *encoder) Write(p []byte) (n int, err error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	n = len(p)
+
+	for len(p) > 0 {
+		if e.nbuf < 5 {
+			k := copy(e.buf[e.nbuf:5], p)
+			e.nbuf += k
+			p = p[k:]
+		}
+
+		if e.nbuf < 5 {
+			break
+		}
+
+		nout := EncodeToSlice(e.buf[:5], e.out[:], e.alphabet)
+		if _, e.err = e.w.Write(e.out[:nout]); e.err != nil {
+			return n - len(p), e.err
+		}
+		e.nbuf = 0
+	}
+
+	return n, nil
+}
+
+// Close flushes any partially buffered input group, emitting the tail
+// quantum. It does not close the underlying io.Writer.
+func (e *encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	if e.nbuf > 0 {
+		nout := EncodeToSlice(e.buf[:e.nbuf], e.out[:], e.alphabet)
+		if _, err := e.w.Write(e.out[:nout]); err != nil {
+			e.err = err
+			return err
+		}
+		e.nbuf = 0
+	}
+
+	return nil
+}
+
+// validTailLen reports whether n (the number of characters in the final,
+// possibly incomplete, group) could have been produced by EncodeToSlice
+// with NoPadding. Character counts of 1, 3 or 6 modulo 8 can never occur and
+// indicate a stream truncated mid-group. With padding, every group is a full
+// 8 characters, which fill checks separately.
+func validTailLen(n int) bool {
+	switch n % 8 {
+	case 0, 2, 4, 5, 7:
+		return true
+	default:
+		return false
+	}
+}
+
+// decoder implements streaming decoding on top of DecodeAlphabetToSlice,
+// buffering input characters until a full 8-character group is available.
+type decoder struct {
+	r        io.Reader
+	alphabet *Alphabet
+	err      error
+	readBuf  [8]byte
+	in       [8]byte
+	nin      int
+	out      [5]byte
+	nout     int
+	outOff   int
+}
+
+// NewDecoder returns a new streaming decoder that reads base32-encoded input
+// using alphabet from r. It tolerates '\r' and '\n' anywhere in the stream
+// and returns io.ErrUnexpectedEOF if the stream ends in the middle of a
+// group.
+func NewDecoder(r io.Reader, alphabet *Alphabet) io.Reader {
+	return &decoder{r: r, alphabet: alphabet}
+}
+
+func (d *decoder) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if d.outOff < d.nout {
+			k := copy(p[n:], d.out[d.outOff:d.nout])
+			d.outOff += k
+			n += k
+			continue
+		}
+
+		if d.err != nil {
+			return n, d.err
+		}
+
+		if err := d.fill(); err != nil {
+			d.err = err
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// fill reads and decodes the next group of input characters into d.out.
+// It returns io.EOF once the stream is exhausted and io.ErrUnexpectedEOF if
+// the stream ends with an invalid trailing group.
+func (d *decoder) fill() error {
+	for d.nin < 8 {
+		m, rerr := d.r.Read(d.readBuf[:8-d.nin])
+		for i := 0; i < m; i++ {
+			c := d.readBuf[i]
+			if c == '\r' || c == '\n' {
+				continue
+			}
+			d.in[d.nin] = c
+			d.nin++
+		}
+
+		if rerr != nil {
+			if rerr != io.EOF {
+				return rerr
+			}
+			if d.nin == 0 {
+				return io.EOF
+			}
+			if !validTailLen(d.nin) || (d.alphabet.padChar != NoPadding && d.nin != 8) {
+				return io.ErrUnexpectedEOF
+			}
+			break
+		}
+	}
+
+	nout, err := DecodeAlphabetToSlice(d.in[:d.nin], d.out[:], d.alphabet)
+	if err != nil {
+		return err
+	}
+
+	d.nout = nout
+	d.outOff = 0
+	d.nin = 0
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package base32
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func TestStreamEncoderMatchesEncodeAlphabet(t *testing.T) {
+	alphabets := []*Alphabet{ZBASE32, RFC4648, BECH32}
+	names := []string{"ZBASE32", "RFC4648", "BECH32"}
+
+	data := []byte("hello world this is a test string for streaming encode compatibility, long enough to span several groups")
+
+	for i, alphabet := range alphabets {
+		t.Run(names[i], func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf, alphabet)
+			if _, err := enc.Write(data); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			if err := enc.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			want := EncodeAlphabet(data, alphabet)
+			if buf.String() != want {
+				t.Errorf("streamed encode = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestStreamEncoderRandomChunking(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	data := make([]byte, 5000)
+	rng.Read(data)
+
+	for _, remainder := range []int{0, 1, 2, 3, 4} {
+		input := data[:len(data)-remainder]
+
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf, ZBASE32)
+
+		rest := input
+		for len(rest) > 0 {
+			n := 1 + rng.Intn(7)
+			if n > len(rest) {
+				n = len(rest)
+			}
+			if _, err := enc.Write(rest[:n]); err != nil {
+				t.Fatalf("Write failed: %v", err)
+			}
+			rest = rest[n:]
+		}
+
+		if err := enc.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		want := EncodeAlphabet(input, ZBASE32)
+		if buf.String() != want {
+			t.Fatalf("remainder %d: streamed encode mismatch, got %d chars, want %d chars", remainder, buf.Len(), len(want))
+		}
+	}
+}
+
+func TestStreamDecoderMatchesDecodeAlphabet(t *testing.T) {
+	alphabets := []*Alphabet{ZBASE32, RFC4648, BECH32}
+	names := []string{"ZBASE32", "RFC4648", "BECH32"}
+
+	data := []byte("hello world this is a test string for streaming decode compatibility, long enough to span several groups")
+
+	for i, alphabet := range alphabets {
+		t.Run(names[i], func(t *testing.T) {
+			encoded := EncodeAlphabet(data, alphabet)
+
+			dec := NewDecoder(bytes.NewReader([]byte(encoded)), alphabet)
+			got, err := io.ReadAll(dec)
+			if err != nil {
+				t.Fatalf("ReadAll failed: %v", err)
+			}
+
+			if !bytes.Equal(got, data) {
+				t.Errorf("streamed decode = %q, want %q", got, data)
+			}
+		})
+	}
+}
+
+func TestStreamDecoderTolerateNewlines(t *testing.T) {
+	encoded := EncodeAlphabet([]byte("hello world"), ZBASE32)
+
+	var noisy bytes.Buffer
+	for i, c := range encoded {
+		noisy.WriteRune(c)
+		if i%3 == 0 {
+			noisy.WriteString("\r\n")
+		}
+	}
+
+	dec := NewDecoder(&noisy, ZBASE32)
+	got, err := io.ReadAll(dec)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+
+	if string(got) != "hello world" {
+		t.Errorf("decode with embedded newlines = %q, want %q", got, "hello world")
+	}
+}
+
+func TestStreamDecoderUnexpectedEOF(t *testing.T) {
+	// A single character can never be a valid trailing group (see validTailLen).
+	encoded := EncodeAlphabet([]byte("aaaaa"), ZBASE32)
+
+	dec := NewDecoder(bytes.NewReader([]byte(encoded[:1])), ZBASE32)
+	_, err := io.ReadAll(dec)
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestStreamRoundtripBoundaryQuanta(t *testing.T) {
+	for n := 1; n <= 12; n++ {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i + 1)
+		}
+
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf, ZBASE32)
+		if _, err := enc.Write(data); err != nil {
+			t.Fatalf("len %d: Write failed: %v", n, err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("len %d: Close failed: %v", n, err)
+		}
+
+		dec := NewDecoder(&buf, ZBASE32)
+		got, err := io.ReadAll(dec)
+		if err != nil {
+			t.Fatalf("len %d: ReadAll failed: %v", n, err)
+		}
+
+		if !bytes.Equal(got, data) {
+			t.Errorf("len %d: roundtrip = %v, want %v", n, got, data)
+		}
+	}
+}